@@ -0,0 +1,126 @@
+package faxto
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// buildURL joins the Client's base URL with action and the given query
+// values using net/url, rather than hand-rolled string concatenation. It's
+// the single place that knows how to compose a request URL, so every
+// endpoint gets query-string handling for free.
+func (c *Client) buildURL(action string, query url.Values) (string, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("faxto: invalid base URL %q: %w", c.baseURL, err)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/") + action
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String(), nil
+}
+
+func (c *Client) setCommonHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+}
+
+// doRequest is the shared transport for every API call. It builds the
+// request with the given context, retries on 429/5xx responses and
+// transport errors using the Client's RetryPolicy (honoring Retry-After
+// when present), and surfaces a typed *LimitExceededError or *APIError for
+// any response that doesn't succeed. On success, the caller owns the
+// returned *http.Response and must close its Body.
+func (c *Client) doRequest(ctx context.Context, method, action string, query url.Values, body []byte, contentType string) (*http.Response, error) {
+	urlStr, err := c.buildURL(action, query)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := c.retryPolicy
+	backoff := policy.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, urlStr, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		c.setCommonHeaders(req)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if attempt >= policy.MaxRetries {
+				return nil, err
+			}
+			if sleepErr := sleepWithJitter(ctx, backoff); sleepErr != nil {
+				return nil, sleepErr
+			}
+			backoff = nextBackoff(backoff, policy)
+			continue
+		}
+
+		if isRetryableStatus(resp.StatusCode) && attempt < policy.MaxRetries {
+			wait := retryAfterDuration(resp.Header, backoff)
+			resp.Body.Close()
+			if sleepErr := sleepWithJitter(ctx, wait); sleepErr != nil {
+				return nil, sleepErr
+			}
+			backoff = nextBackoff(backoff, policy)
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			return nil, newResponseError(resp, urlStr)
+		}
+
+		return resp, nil
+	}
+}
+
+// doStreamRequest performs a single request attempt with a streamed,
+// non-replayable body. Unlike doRequest, it does not retry: once bytes have
+// started flowing from body there is no general way to rewind it, so a
+// failed attempt would risk sending a truncated request twice.
+func (c *Client) doStreamRequest(ctx context.Context, method, action, contentType string, body io.Reader) (*http.Response, error) {
+	urlStr, err := c.buildURL(action, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	c.setCommonHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, newResponseError(resp, urlStr)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,128 @@
+package faxto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy(maxRetries int) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     maxRetries,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func TestDoRequestRetriesOn429ThenSucceeds(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"success","balance":12.5}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL), WithRetryPolicy(fastRetryPolicy(2)))
+
+	balance, err := c.GetBalanceCtx(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if balance != 12.5 {
+		t.Fatalf("got balance %v, want 12.5", balance)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("got %d requests, want 2", got)
+	}
+}
+
+func TestDoRequestReturnsAPIErrorAfterRetriesExhausted(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"status":"error","message":"boom"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL), WithRetryPolicy(fastRetryPolicy(2)))
+
+	_, err := c.GetBalanceCtx(context.Background())
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("got status code %d, want %d", apiErr.StatusCode, http.StatusInternalServerError)
+	}
+	if apiErr.Message != "boom" {
+		t.Fatalf("got message %q, want %q", apiErr.Message, "boom")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 { // initial attempt + 2 retries
+		t.Fatalf("got %d requests, want 3", got)
+	}
+}
+
+func TestDoRequestReturnsLimitExceededErrorOn429(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":"limit_exceeded","message":"too many requests"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL), WithRetryPolicy(fastRetryPolicy(0)))
+
+	_, err := c.GetBalanceCtx(context.Background())
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var limitErr *LimitExceededError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected *LimitExceededError, got %T: %v", err, err)
+	}
+}
+
+func TestDoRequestHonorsRetryAfterHeader(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"success","balance":1}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{
+		MaxRetries:     1,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}))
+
+	start := time.Now()
+	if _, err := c.GetBalanceCtx(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected doRequest to wait for the Retry-After header, only waited %v", elapsed)
+	}
+}
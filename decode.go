@@ -0,0 +1,53 @@
+package faxto
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodeJSON reads resp.Body exactly once and closes it. If requireSuccess
+// is true, it first checks the response's top-level "status" field and
+// fails if it isn't "success". If v is non-nil, the body is then decoded
+// into it. Any decode failure returns an error carrying a snippet of the
+// raw body instead of a bare JSON error, so callers can see what was
+// actually sent back.
+func decodeJSON(resp *http.Response, v interface{}, requireSuccess bool) error {
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("faxto: failed to read response body: %w", err)
+	}
+
+	if requireSuccess {
+		var wrapper struct {
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(raw, &wrapper); err != nil {
+			return fmt.Errorf("faxto: failed to decode response: %s", bodySnippet(raw))
+		}
+		if wrapper.Status != "success" {
+			return fmt.Errorf("faxto: expected status 'success', got '%s'", wrapper.Status)
+		}
+	}
+
+	if v != nil {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("faxto: failed to decode response: %s", bodySnippet(raw))
+		}
+	}
+
+	return nil
+}
+
+func bodySnippet(raw []byte) string {
+	const max = 2048
+	s := strings.TrimSpace(string(raw))
+	if len(s) > max {
+		return s[:max] + "..."
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package faxto
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned whenever the fax.to API responds with a status code
+// or a response body indicating failure. It carries enough detail for
+// callers to log or branch on without having to re-parse the response body.
+type APIError struct {
+	StatusCode int
+	URL        string
+	Status     string
+	Message    string
+	Body       string // raw response body snippet, used when decoding fails
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("faxto: request to %s failed with status %d: %s", e.URL, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("faxto: request to %s failed with status %d: %s", e.URL, e.StatusCode, e.Body)
+}
+
+// LimitExceededError is returned when the API reports that a usage or rate
+// limit has been exceeded, either via a 429 response or a limit-related
+// status/message in the response body. Callers can type-assert or use
+// errors.As to distinguish it from other APIErrors.
+type LimitExceededError struct {
+	*APIError
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("faxto: limit exceeded for %s: %s", e.URL, e.Message)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return e.APIError
+}
+
+// newResponseError reads and closes resp.Body, producing a typed error that
+// describes the failed request. It never fails: if the body isn't valid
+// JSON, the raw snippet is kept for debugging.
+func newResponseError(resp *http.Response, url string) error {
+	defer resp.Body.Close()
+
+	const maxSnippet = 4096
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxSnippet))
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		URL:        url,
+		Body:       strings.TrimSpace(string(raw)),
+	}
+
+	var wrapper struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &wrapper) == nil {
+		apiErr.Status = wrapper.Status
+		apiErr.Message = wrapper.Message
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests ||
+		strings.Contains(strings.ToLower(apiErr.Status), "limit") ||
+		strings.Contains(strings.ToLower(apiErr.Message), "limit") {
+		return &LimitExceededError{APIError: apiErr}
+	}
+
+	return apiErr
+}
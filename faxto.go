@@ -1,19 +1,17 @@
+// Package faxto is a client for the fax.to API (https://fax.to/api).
 package faxto
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
-const (
-	rawBaseUrl = "https://fax.to/api/v2$action$?api_key=$key$"
-)
+// DefaultBaseURL is the fax.to API root used unless overridden with
+// WithBaseURL.
+const DefaultBaseURL = "https://fax.to/api/v2"
 
 type (
 	balanceResponse struct {
@@ -46,15 +44,13 @@ type (
 		Status  string            `json:"status"`
 		History []FaxHistoryEntry `json:"history"`
 	}
-	fileUploadResponse struct {
-		Status     string `json:"status"`
-		DocumentId uint64 `json:"document_id"`
-		TotalPages uint64 `json:"total_pages"`
-	}
-
+	// Client is a fax.to API client. Construct one with NewClient.
 	Client struct {
-		baseUrl    string
-		httpClient *http.Client
+		apiKey      string
+		baseURL     string
+		httpClient  *http.Client
+		userAgent   string
+		retryPolicy RetryPolicy
 	}
 	File struct {
 		Id       uint64    `json:"id"`
@@ -65,227 +61,139 @@ type (
 	}
 )
 
-func NewClient(apiKey string) Client {
-	return Client{
-		baseUrl:    strings.ReplaceAll(rawBaseUrl, "$key$", apiKey),
-		httpClient: &http.Client{Timeout: 10 * time.Second},
-	}
-}
-
-func (c *Client) SendFax(number string, fileId uint64) error {
-	data := url.Values{
-		"fax_number":  {number},
-		"document_id": {fmt.Sprintf("%d", fileId)},
-	}
-
-	resp, err := c.httpClient.PostForm(strings.ReplaceAll(c.baseUrl, "$action$", "/fax"), data)
-	if err != nil {
-		return err
+// NewClient creates a fax.to API client authenticated with apiKey. Behavior
+// is customized via ClientOption values, e.g. WithHTTPClient, WithBaseURL,
+// WithRetryPolicy and WithUserAgent.
+func NewClient(apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
+		apiKey:      apiKey,
+		baseURL:     DefaultBaseURL,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		retryPolicy: DefaultRetryPolicy(),
 	}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	return nil
+	return c
 }
 
-func (c *Client) GetBalance() (float64, error) {
-	req, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(c.baseUrl, "$action$", "/balance"), nil)
-	if err != nil {
-		return 0, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+// GetBalanceCtx returns the account's current cash balance.
+func (c *Client) GetBalanceCtx(ctx context.Context) (float64, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/balance", nil, nil, "")
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return 0, fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
 
 	var b balanceResponse
-	err = json.NewDecoder(resp.Body).Decode(&b)
-	if err != nil {
+	if err := decodeJSON(resp, &b, true); err != nil {
 		return 0, err
 	}
 
-	if b.Status != "success" {
-		return 0, fmt.Errorf("expected status 'success', got '%s'", b.Status)
-	}
-
 	return b.Balance, nil
 }
 
-func (c *Client) GetFaxCost(number string, docId uint64) (float64, error) {
-	req, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(c.baseUrl, "$action$", fmt.Sprintf("/fax/%d/costs", docId))+"&fax_number="+number, nil)
-	if err != nil {
-		return 0, err
-	}
+// GetBalance is the context.Background() form of GetBalanceCtx.
+func (c *Client) GetBalance() (float64, error) {
+	return c.GetBalanceCtx(context.Background())
+}
 
-	req.Header.Set("Accept", "application/json")
+// GetFaxCostCtx returns the estimated cost of sending docId to number.
+func (c *Client) GetFaxCostCtx(ctx context.Context, number string, docId uint64) (float64, error) {
+	query := url.Values{"fax_number": {number}}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/fax/%d/costs", docId), query, nil, "")
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return 0, fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
 
 	var cost faxCostResponse
-	err = json.NewDecoder(resp.Body).Decode(&cost)
-	if err != nil {
+	if err := decodeJSON(resp, &cost, true); err != nil {
 		return 0, err
 	}
 
 	return cost.Cost, nil
 }
 
-func (c *Client) GetFaxStatus(faxJobId int) (string, error) {
-	req, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(c.baseUrl, "$action$", fmt.Sprintf("/fax/%d/status", faxJobId)), nil)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Set("Accept", "application/json")
+// GetFaxCost is the context.Background() form of GetFaxCostCtx.
+func (c *Client) GetFaxCost(number string, docId uint64) (float64, error) {
+	return c.GetFaxCostCtx(context.Background(), number, docId)
+}
 
-	resp, err := c.httpClient.Do(req)
+// GetFaxStatusCtx returns the current status of a previously submitted fax
+// job. Use WaitForFax to block until the status is terminal.
+func (c *Client) GetFaxStatusCtx(ctx context.Context, faxJobId int) (FaxStatus, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/fax/%d/status", faxJobId), nil, nil, "")
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
 
 	var fs faxStatusResponse
-	err = json.NewDecoder(resp.Body).Decode(&fs)
-	if err != nil {
+	if err := decodeJSON(resp, &fs, false); err != nil {
 		return "", err
 	}
 
-	return fs.Status, nil
+	return FaxStatus(fs.Status), nil
 }
 
-func (c *Client) GetFaxHistory() ([]FaxHistoryEntry, error) {
-	req, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(c.baseUrl, "$action$", "/fax-history"), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Accept", "application/json")
+// GetFaxStatus is the context.Background() form of GetFaxStatusCtx.
+func (c *Client) GetFaxStatus(faxJobId int) (FaxStatus, error) {
+	return c.GetFaxStatusCtx(context.Background(), faxJobId)
+}
 
-	resp, err := c.httpClient.Do(req)
+// GetFaxHistoryCtx returns the account's fax history.
+func (c *Client) GetFaxHistoryCtx(ctx context.Context) ([]FaxHistoryEntry, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/fax-history", nil, nil, "")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
 
 	var fh faxHistoryResponse
-	err = json.NewDecoder(resp.Body).Decode(&fh)
-	if err != nil {
+	if err := decodeJSON(resp, &fh, true); err != nil {
 		return nil, err
 	}
 
-	if fh.Status != "success" {
-		return nil, fmt.Errorf("expected status 'success', got '%s'", fh.Status)
-	}
-
 	return fh.History, nil
 }
 
-func (c *Client) UploadFile(file string) (uint64, error) {
-	content, err := ioutil.ReadFile(file)
-	if err != nil {
-		return 0, err
-	}
-
-	req, err := http.NewRequest(http.MethodPost, strings.ReplaceAll(c.baseUrl, "$action$", "/files"), bytes.NewBuffer(content)) // MethodPut?
-	if err != nil {
-		return 0, err
-	}
-
-	//req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return 0, fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
-
-	var fu fileUploadResponse
-	err = json.NewDecoder(resp.Body).Decode(&fu)
-	if err != nil {
-		return 0, err
-	}
-
-	if fu.Status != "success" {
-		return 0, fmt.Errorf("expected status 'success', got '%s'", fu.Status)
-	}
-
-	return fu.DocumentId, nil
+// GetFaxHistory is the context.Background() form of GetFaxHistoryCtx.
+func (c *Client) GetFaxHistory() ([]FaxHistoryEntry, error) {
+	return c.GetFaxHistoryCtx(context.Background())
 }
 
-func (c *Client) GetFiles() ([]File, error) {
-	req, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(c.baseUrl, "$action$", "/files"), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+// GetFilesCtx returns metadata for every file uploaded to the account.
+func (c *Client) GetFilesCtx(ctx context.Context) ([]File, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/files", nil, nil, "")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
 
 	files := make([]File, 0)
-	err = json.NewDecoder(resp.Body).Decode(&files)
-	if err != nil {
+	if err := decodeJSON(resp, &files, false); err != nil {
 		return nil, err
 	}
 
 	return files, nil
 }
 
-func (c *Client) DeleteFile(fileId uint64) error {
-	req, err := http.NewRequest(http.MethodDelete, strings.ReplaceAll(c.baseUrl, "$action$", fmt.Sprintf("/files/%d", fileId)), nil)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Accept", "application/json")
+// GetFiles is the context.Background() form of GetFilesCtx.
+func (c *Client) GetFiles() ([]File, error) {
+	return c.GetFilesCtx(context.Background())
+}
 
-	resp, err := c.httpClient.Do(req)
+// DeleteFileCtx deletes a previously uploaded file.
+func (c *Client) DeleteFileCtx(ctx context.Context, fileId uint64) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/files/%d", fileId), nil, nil, "")
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("expected status < 400, got %d", resp.StatusCode)
-	}
+	resp.Body.Close()
 
 	return nil
 }
+
+// DeleteFile is the context.Background() form of DeleteFileCtx.
+func (c *Client) DeleteFile(fileId uint64) error {
+	return c.DeleteFileCtx(context.Background(), fileId)
+}
@@ -0,0 +1,38 @@
+package faxto
+
+import "net/http"
+
+// ClientOption configures a Client at construction time. See WithHTTPClient,
+// WithRetryPolicy, WithBaseURL and WithUserAgent.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the *http.Client used to perform requests. Useful
+// for injecting a custom RoundTripper (e.g. for logging, metrics, or mTLS).
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithRetryPolicy overrides the backoff behavior used for 429/5xx responses
+// and transport errors. See DefaultRetryPolicy for the default.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// WithBaseURL overrides the API root (default DefaultBaseURL). This is
+// primarily useful for pointing the Client at an httptest.Server in tests.
+func WithBaseURL(rawURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = rawURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
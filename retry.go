@@ -0,0 +1,76 @@
+package faxto
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how doRequest backs off when the API responds with
+// 429 or a 5xx status, or when the underlying transport returns an error.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used when a Client is created
+// without WithRetryPolicy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryAfterDuration honors a Retry-After header (either delta-seconds or an
+// HTTP-date), falling back to the given backoff duration if the header is
+// absent or unparsable.
+func retryAfterDuration(h http.Header, fallback time.Duration) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return fallback
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+// sleepWithJitter waits for a random duration in [0.5*d, 1.5*d), returning
+// early with ctx.Err() if ctx is cancelled first.
+func sleepWithJitter(ctx context.Context, d time.Duration) error {
+	jittered := time.Duration(float64(d) * (0.5 + rand.Float64()))
+
+	timer := time.NewTimer(jittered)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func nextBackoff(cur time.Duration, policy RetryPolicy) time.Duration {
+	next := time.Duration(float64(cur) * policy.Multiplier)
+	if next > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+	return next
+}
@@ -0,0 +1,135 @@
+package faxto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SendFaxRequest describes a fax job to submit. Recipient and DocumentId
+// are required; the rest are optional.
+type SendFaxRequest struct {
+	Recipient     string
+	DocumentId    uint64
+	ScheduledAt   *time.Time
+	CallerId      string
+	RetryCount    int
+	CoverPageText string
+}
+
+// FaxJob is the job fax.to created in response to SendFaxCtx.
+type FaxJob struct {
+	Id            uint64
+	EstimatedCost float64
+	Status        FaxStatus
+	CreatedAt     time.Time
+}
+
+type sendFaxResponse struct {
+	Status        string    `json:"status"`
+	Id            uint64    `json:"id"`
+	EstimatedCost float64   `json:"estimated_cost"`
+	FaxStatus     string    `json:"fax_status"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SendFaxCtx submits a fax job and returns its id, estimated cost and
+// initial status so callers can track it with GetFaxStatusCtx or
+// WaitForFax.
+//
+// Sending a fax isn't idempotent: a second submission bills and faxes the
+// recipient again. So unlike most calls, SendFaxCtx does not go through
+// doRequest's automatic retries — a 5xx/timeout/connection-reset after the
+// server has already queued the fax must not cause a silent resubmission.
+// Callers that want retried delivery should retry at the SendFaxCtx level
+// themselves, or pass a RetryCount so fax.to handles retries server-side.
+func (c *Client) SendFaxCtx(ctx context.Context, req SendFaxRequest) (FaxJob, error) {
+	form := url.Values{
+		"fax_number":  {req.Recipient},
+		"document_id": {fmt.Sprintf("%d", req.DocumentId)},
+	}
+	if req.ScheduledAt != nil {
+		form.Set("scheduled_at", req.ScheduledAt.UTC().Format(time.RFC3339))
+	}
+	if req.CallerId != "" {
+		form.Set("caller_id", req.CallerId)
+	}
+	if req.RetryCount > 0 {
+		form.Set("retry_count", fmt.Sprintf("%d", req.RetryCount))
+	}
+	if req.CoverPageText != "" {
+		form.Set("cover_page_text", req.CoverPageText)
+	}
+
+	resp, err := c.doStreamRequest(ctx, http.MethodPost, "/fax", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return FaxJob{}, err
+	}
+
+	var sf sendFaxResponse
+	if err := decodeJSON(resp, &sf, true); err != nil {
+		return FaxJob{}, err
+	}
+
+	return FaxJob{
+		Id:            sf.Id,
+		EstimatedCost: sf.EstimatedCost,
+		Status:        FaxStatus(sf.FaxStatus),
+		CreatedAt:     sf.CreatedAt,
+	}, nil
+}
+
+// SendFax is the context.Background() form of SendFaxCtx.
+func (c *Client) SendFax(req SendFaxRequest) (FaxJob, error) {
+	return c.SendFaxCtx(context.Background(), req)
+}
+
+// SendFaxBatchResult pairs a submitted SendFaxRequest with its outcome.
+type SendFaxBatchResult struct {
+	Request SendFaxRequest
+	Job     FaxJob
+	Err     error
+}
+
+// SendFaxBatch submits reqs concurrently, running at most concurrency
+// requests at a time (concurrency less than 1 is treated as 1). It returns
+// one SendFaxBatchResult per request, in the same order as reqs, along
+// with every individual error joined via errors.Join (nil if all requests
+// succeeded).
+func (c *Client) SendFaxBatch(ctx context.Context, reqs []SendFaxRequest, concurrency int) ([]SendFaxBatchResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]SendFaxBatchResult, len(reqs))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, req SendFaxRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			job, err := c.SendFaxCtx(ctx, req)
+			results[i] = SendFaxBatchResult{Request: req, Job: job, Err: err}
+		}(i, req)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,29 @@
+package faxto
+
+// FaxStatus is the lifecycle state of a submitted fax job.
+type FaxStatus string
+
+const (
+	StatusQueued     FaxStatus = "queued"
+	StatusInProgress FaxStatus = "in_progress"
+	StatusSuccess    FaxStatus = "success"
+	StatusFailed     FaxStatus = "failed"
+	StatusNoAnswer   FaxStatus = "no_answer"
+	StatusBusy       FaxStatus = "busy"
+)
+
+// IsTerminal reports whether the fax job has reached a final state and will
+// no longer change.
+func (s FaxStatus) IsTerminal() bool {
+	switch s {
+	case StatusSuccess, StatusFailed, StatusNoAnswer, StatusBusy:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSuccess reports whether the fax job was delivered successfully.
+func (s FaxStatus) IsSuccess() bool {
+	return s == StatusSuccess
+}
@@ -0,0 +1,138 @@
+package faxto
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// UploadRequest pairs a file name with the reader supplying its content,
+// matching the filename and r arguments of UploadReaderCtx. No function in
+// this package takes an UploadRequest.
+type UploadRequest struct {
+	Filename string
+	Reader   io.Reader
+}
+
+type uploadConfig struct {
+	totalSize  int64
+	onProgress func(bytesWritten, totalBytes int64)
+}
+
+// UploadOption configures an UploadReaderCtx call. See WithProgressCallback
+// and WithTotalSize.
+type UploadOption func(*uploadConfig)
+
+// WithProgressCallback registers a callback invoked after each chunk is
+// written to the upload. totalBytes is 0 if it wasn't supplied via
+// WithTotalSize and couldn't be determined from the reader.
+func WithProgressCallback(cb func(bytesWritten, totalBytes int64)) UploadOption {
+	return func(cfg *uploadConfig) {
+		cfg.onProgress = cb
+	}
+}
+
+// WithTotalSize tells the progress callback the total size of the upload in
+// advance, e.g. when streaming from something other than an *os.File.
+func WithTotalSize(size int64) UploadOption {
+	return func(cfg *uploadConfig) {
+		cfg.totalSize = size
+	}
+}
+
+// progressReader wraps an io.Reader, reporting cumulative bytes read to
+// onProgress after each Read.
+type progressReader struct {
+	r          io.Reader
+	total      int64
+	written    int64
+	onProgress func(bytesWritten, totalBytes int64)
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.written += int64(n)
+		p.onProgress(p.written, p.total)
+	}
+	return n, err
+}
+
+type uploadResponse struct {
+	Status string `json:"status"`
+	File   File   `json:"file"`
+}
+
+// UploadReaderCtx streams r to fax.to as a multipart/form-data upload,
+// avoiding the need to buffer the whole file in memory, and returns the
+// full File metadata assigned by the API.
+func (c *Client) UploadReaderCtx(ctx context.Context, filename string, r io.Reader, opts ...UploadOption) (File, error) {
+	cfg := uploadConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.totalSize == 0 {
+		if f, ok := r.(*os.File); ok {
+			if stat, err := f.Stat(); err == nil {
+				cfg.totalSize = stat.Size()
+			}
+		}
+	}
+
+	if cfg.onProgress != nil {
+		r = &progressReader{r: r, total: cfg.totalSize, onProgress: cfg.onProgress}
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := mw.CreateFormFile("file", filename)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, r); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+
+	resp, err := c.doStreamRequest(ctx, http.MethodPost, "/files", mw.FormDataContentType(), pr)
+	if err != nil {
+		return File{}, err
+	}
+
+	var ur uploadResponse
+	if err := decodeJSON(resp, &ur, true); err != nil {
+		return File{}, err
+	}
+
+	return ur.File, nil
+}
+
+// UploadFileCtx opens file from disk and uploads it via UploadReaderCtx.
+func (c *Client) UploadFileCtx(ctx context.Context, file string) (File, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return File{}, err
+	}
+	defer f.Close()
+
+	var opts []UploadOption
+	if stat, err := f.Stat(); err == nil {
+		opts = append(opts, WithTotalSize(stat.Size()))
+	}
+
+	return c.UploadReaderCtx(ctx, filepath.Base(file), f, opts...)
+}
+
+// UploadFile is the context.Background() form of UploadFileCtx.
+func (c *Client) UploadFile(file string) (File, error) {
+	return c.UploadFileCtx(context.Background(), file)
+}
@@ -0,0 +1,80 @@
+package faxto
+
+import (
+	"context"
+	"time"
+)
+
+type waitConfig struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	factor          float64
+}
+
+// WaitOption configures WaitForFax. See WithInitialInterval,
+// WithMaxInterval and WithBackoffFactor.
+type WaitOption func(*waitConfig)
+
+// WithInitialInterval sets the delay before the first poll after the
+// initial status check. Defaults to 2s.
+func WithInitialInterval(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.initialInterval = d
+	}
+}
+
+// WithMaxInterval caps how long WaitForFax waits between polls. Defaults to
+// 30s.
+func WithMaxInterval(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.maxInterval = d
+	}
+}
+
+// WithBackoffFactor sets the multiplier applied to the poll interval after
+// each non-terminal status. Defaults to 1.5.
+func WithBackoffFactor(f float64) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.factor = f
+	}
+}
+
+// WaitForFax polls GetFaxStatusCtx until faxJobId reaches a terminal
+// FaxStatus, ctx is cancelled, or GetFaxStatusCtx returns an error. The
+// overall deadline is controlled by ctx; poll cadence is controlled by the
+// WaitOption values.
+func (c *Client) WaitForFax(ctx context.Context, faxJobId int, opts ...WaitOption) (FaxStatus, error) {
+	cfg := waitConfig{
+		initialInterval: 2 * time.Second,
+		maxInterval:     30 * time.Second,
+		factor:          1.5,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	interval := cfg.initialInterval
+
+	for {
+		status, err := c.GetFaxStatusCtx(ctx, faxJobId)
+		if err != nil {
+			return status, err
+		}
+		if status.IsTerminal() {
+			return status, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * cfg.factor)
+		if interval > cfg.maxInterval {
+			interval = cfg.maxInterval
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Command example shows how to mount a webhook.Mux under an
+// http.ServeMux to receive fax.to delivery callbacks.
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/KaiserWerk/go-faxto/webhook"
+)
+
+func main() {
+	mux := webhook.NewMux("my-shared-secret")
+
+	mux.OnFaxSent(func(evt webhook.Event) {
+		log.Printf("fax %d sent to %s", evt.FaxId, evt.Recipient)
+	})
+	mux.OnFaxDelivered(func(evt webhook.Event) {
+		log.Printf("fax %d delivered, cost %.2f", evt.FaxId, evt.Cost)
+	})
+	mux.OnFaxFailed(func(evt webhook.Event) {
+		log.Printf("fax %d failed: %s", evt.FaxId, evt.Error)
+	})
+	mux.OnFileUploaded(func(evt webhook.Event) {
+		log.Printf("file %d uploaded", evt.DocumentId)
+	})
+
+	router := http.NewServeMux()
+	router.Handle("/webhooks/faxto", mux)
+
+	log.Fatal(http.ListenAndServe(":8080", router))
+}
@@ -0,0 +1,192 @@
+// Package webhook receives and verifies fax.to delivery status callbacks,
+// dispatching them to user-registered handlers.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// EventType identifies the kind of delivery event a Mux dispatches.
+type EventType string
+
+const (
+	EventFaxSent      EventType = "fax.sent"
+	EventFaxFailed    EventType = "fax.failed"
+	EventFaxDelivered EventType = "fax.delivered"
+	EventFileUploaded EventType = "file.uploaded"
+)
+
+// Event is the payload fax.to POSTs for a delivery status callback.
+type Event struct {
+	Type       EventType `json:"event"`
+	FaxId      uint64    `json:"fax_id,omitempty"`
+	DocumentId uint64    `json:"document_id,omitempty"`
+	Recipient  string    `json:"recipient,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	Cost       float64   `json:"cost,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Handler receives a dispatched Event.
+type Handler func(Event)
+
+const (
+	// DefaultSignatureHeader is the header Mux reads the HMAC signature
+	// from unless WithSignatureHeader overrides it.
+	DefaultSignatureHeader = "X-Faxto-Signature"
+	// DefaultTolerance is the maximum age of an Event's Timestamp that
+	// Mux accepts unless WithTolerance overrides it.
+	DefaultTolerance = 5 * time.Minute
+)
+
+// ErrInvalidSignature is returned (as a 401 response) when the request's
+// signature header is missing or doesn't match the computed HMAC.
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// ErrStaleEvent is returned (as a 401 response) when an Event's Timestamp
+// falls outside the configured tolerance window, guarding against replay.
+var ErrStaleEvent = errors.New("webhook: event timestamp outside tolerance window")
+
+// Mux verifies and dispatches fax.to webhook deliveries. It implements
+// http.Handler and can be mounted under any path, e.g.
+// http.Handle("/webhooks/faxto", mux).
+type Mux struct {
+	secret          []byte
+	signatureHeader string
+	tolerance       time.Duration
+
+	onFaxSent      []Handler
+	onFaxFailed    []Handler
+	onFaxDelivered []Handler
+	onFileUploaded []Handler
+}
+
+// MuxOption configures a Mux. See WithSignatureHeader and WithTolerance.
+type MuxOption func(*Mux)
+
+// WithSignatureHeader overrides the header Mux reads the HMAC signature
+// from. Defaults to DefaultSignatureHeader.
+func WithSignatureHeader(name string) MuxOption {
+	return func(m *Mux) {
+		m.signatureHeader = name
+	}
+}
+
+// WithTolerance overrides how far an Event's Timestamp may drift from now
+// before it's rejected as stale. Defaults to DefaultTolerance; zero
+// disables the check.
+func WithTolerance(d time.Duration) MuxOption {
+	return func(m *Mux) {
+		m.tolerance = d
+	}
+}
+
+// NewMux creates a Mux that verifies incoming requests with a hex-encoded
+// HMAC-SHA256 signature of the request body, keyed with secret.
+func NewMux(secret string, opts ...MuxOption) *Mux {
+	m := &Mux{
+		secret:          []byte(secret),
+		signatureHeader: DefaultSignatureHeader,
+		tolerance:       DefaultTolerance,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// OnFaxSent registers a handler invoked for EventFaxSent events.
+func (m *Mux) OnFaxSent(h Handler) { m.onFaxSent = append(m.onFaxSent, h) }
+
+// OnFaxFailed registers a handler invoked for EventFaxFailed events.
+func (m *Mux) OnFaxFailed(h Handler) { m.onFaxFailed = append(m.onFaxFailed, h) }
+
+// OnFaxDelivered registers a handler invoked for EventFaxDelivered events.
+func (m *Mux) OnFaxDelivered(h Handler) { m.onFaxDelivered = append(m.onFaxDelivered, h) }
+
+// OnFileUploaded registers a handler invoked for EventFileUploaded events.
+func (m *Mux) OnFileUploaded(h Handler) { m.onFileUploaded = append(m.onFileUploaded, h) }
+
+// ServeHTTP verifies the request's signature and timestamp, then dispatches
+// the decoded Event to any handlers registered for its type. It reads at
+// most 1 MiB of r.Body and always closes it.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "webhook: failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := m.verifySignature(r.Header.Get(m.signatureHeader), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var evt Event
+	if err := json.Unmarshal(body, &evt); err != nil {
+		http.Error(w, "webhook: invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if m.tolerance > 0 && absDuration(time.Since(evt.Timestamp)) > m.tolerance {
+		http.Error(w, ErrStaleEvent.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	m.dispatch(evt)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Mux) verifySignature(signature string, body []byte) error {
+	if signature == "" {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (m *Mux) dispatch(evt Event) {
+	var handlers []Handler
+
+	switch evt.Type {
+	case EventFaxSent:
+		handlers = m.onFaxSent
+	case EventFaxFailed:
+		handlers = m.onFaxFailed
+	case EventFaxDelivered:
+		handlers = m.onFaxDelivered
+	case EventFileUploaded:
+		handlers = m.onFileUploaded
+	}
+
+	for _, h := range handlers {
+		h(evt)
+	}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestMuxServeHTTP(t *testing.T) {
+	const secret = "shh"
+
+	tests := []struct {
+		name       string
+		signature  string // "" signs with secret, "omit" omits the header, "wrong" signs with another secret
+		timestamp  time.Time
+		wantStatus int
+	}{
+		{
+			name:       "valid signature and timestamp is accepted",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing signature header is rejected",
+			signature:  "omit",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong signature is rejected",
+			signature:  "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "stale timestamp is rejected",
+			timestamp:  time.Now().Add(-time.Hour),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := tt.timestamp
+			if ts.IsZero() {
+				ts = time.Now()
+			}
+
+			evt := Event{Type: EventFaxSent, FaxId: 1, Timestamp: ts}
+			body, err := json.Marshal(evt)
+			if err != nil {
+				t.Fatalf("marshal event: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/faxto", bytes.NewReader(body))
+			switch tt.signature {
+			case "omit":
+				// no signature header set
+			case "wrong":
+				req.Header.Set(DefaultSignatureHeader, sign("wrong-secret", body))
+			default:
+				req.Header.Set(DefaultSignatureHeader, sign(secret, body))
+			}
+
+			var dispatched bool
+			mux := NewMux(secret)
+			mux.OnFaxSent(func(Event) { dispatched = true })
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			wantDispatched := tt.wantStatus == http.StatusOK
+			if dispatched != wantDispatched {
+				t.Fatalf("got dispatched=%v, want %v", dispatched, wantDispatched)
+			}
+		})
+	}
+}